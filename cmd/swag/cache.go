@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/txix-open/swag/internal/parsercache"
+	"github.com/urfave/cli/v2"
+)
+
+var cacheDirFlag = &cli.StringFlag{
+	Name:  "cacheDir",
+	Usage: "directory the parse cache is stored in (default: $XDG_CACHE_HOME/swag)",
+}
+
+var noCacheFlag = &cli.BoolFlag{
+	Name:  "noCache",
+	Usage: "bypass the on-disk parse cache entirely",
+}
+
+var cacheCommand = &cli.Command{
+	Name:  "cache",
+	Usage: "inspect or manage the on-disk parse cache",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "clean",
+			Usage:  "remove expired entries from the parse cache",
+			Action: runCacheClean,
+			Flags:  []cli.Flag{cacheDirFlag},
+		},
+	},
+}
+
+func runCacheClean(ctx *cli.Context) error {
+	dir := ctx.String("cacheDir")
+	if dir == "" {
+		var err error
+		dir, err = parsercache.Dir()
+		if err != nil {
+			return err
+		}
+	}
+	cache, err := parsercache.New(dir, 0)
+	if err != nil {
+		return err
+	}
+	removed, err := cache.Clean()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("removed %d expired entr%s from %s\n", removed, plural(removed), dir)
+	return nil
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return "y"
+	}
+	return "ies"
+}
+
+// cacheDirFor resolves the effective parse cache directory for a command
+// from its --cacheDir flag, falling back to parsercache.Dir.
+func cacheDirFor(ctx *cli.Context) (string, error) {
+	if dir := ctx.String("cacheDir"); dir != "" {
+		return dir, nil
+	}
+	return parsercache.Dir()
+}
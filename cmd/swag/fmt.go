@@ -0,0 +1,45 @@
+package main
+
+import (
+	"github.com/txix-open/swag/format"
+	"github.com/urfave/cli/v2"
+)
+
+var fmtFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "dir",
+		Aliases: []string{"d"},
+		Value:   "./",
+		Usage:   "directories to format,comma separated",
+	},
+	&cli.StringFlag{
+		Name:  "exclude",
+		Usage: "exclude dirs and files in dir,comma separated",
+	},
+	&cli.BoolFlag{
+		Name:    "check",
+		Aliases: []string{"c"},
+		Usage:   "report files that need formatting instead of rewriting them, printing a diff for each",
+	},
+	&cli.BoolFlag{
+		Name:    "list",
+		Aliases: []string{"l"},
+		Usage:   "like --check but prints only the paths that need formatting",
+	},
+}
+
+var fmtCommand = &cli.Command{
+	Name:   "fmt",
+	Usage:  "format swag comments in Go source files",
+	Action: runFmt,
+	Flags:  fmtFlags,
+}
+
+func runFmt(ctx *cli.Context) error {
+	return format.New().Build(&format.Config{
+		SearchDir: ctx.String("dir"),
+		Excludes:  ctx.String("exclude"),
+		CheckOnly: ctx.Bool("check"),
+		List:      ctx.Bool("list"),
+	})
+}
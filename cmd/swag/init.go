@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/txix-open/swag/gen"
+	"github.com/txix-open/swag/internal/parsercache"
+	"github.com/urfave/cli/v2"
+)
+
+var initFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "dir",
+		Aliases: []string{"d"},
+		Value:   "./",
+		Usage:   "directories you want to parse,comma separated and general-info file must be in the first one",
+	},
+	&cli.StringFlag{
+		Name:    "instanceName",
+		Aliases: []string{"instance"},
+		Value:   "swagger",
+		Usage:   "name of the swag instance, matching the name passed to swag.ReadDoc",
+	},
+	&cli.StringFlag{
+		Name:    "output",
+		Aliases: []string{"o"},
+		Value:   "./docs",
+		Usage:   "output directory for docs.go/swagger.json/swagger.yaml (outputFormat=dir), or the archive destination otherwise; \"-\" streams an archive to stdout",
+	},
+	&cli.StringFlag{
+		Name:  "outputFormat",
+		Value: string(gen.OutputFormatDir),
+		Usage: "output format: dir, tar, tgz, or zip",
+	},
+	cacheDirFlag,
+	noCacheFlag,
+}
+
+var initCommand = &cli.Command{
+	Name:   "init",
+	Usage:  "create docs.go, swagger.json, swagger.yaml",
+	Action: runInit,
+	Flags:  initFlags,
+}
+
+func runInit(ctx *cli.Context) error {
+	format := gen.OutputFormat(ctx.String("outputFormat"))
+	if format == "" {
+		format = gen.OutputFormatDir
+	}
+
+	cacheDir, err := cacheDirFor(ctx)
+	if err != nil {
+		return err
+	}
+
+	searchDir := ctx.String("dir")
+	instanceName := ctx.String("instanceName")
+	output := ctx.String("output")
+	noCache := ctx.Bool("noCache")
+
+	if !noCache {
+		if err := warnIfNoAnnotations(cacheDir, searchDir); err != nil {
+			return err
+		}
+	}
+
+	if format == gen.OutputFormatDir {
+		if err := gen.New().Build(&gen.Config{
+			SearchDir:    searchDir,
+			OutputDir:    output,
+			InstanceName: instanceName,
+			CacheDir:     cacheDir,
+			NoCache:      noCache,
+		}); err != nil {
+			return err
+		}
+		return gen.AppendInstanceInit(output, instanceName)
+	}
+
+	genDir, err := os.MkdirTemp("", "swag-init-*")
+	if err != nil {
+		return fmt.Errorf("init: %w", err)
+	}
+	defer os.RemoveAll(genDir)
+
+	if err := gen.New().Build(&gen.Config{
+		SearchDir:    searchDir,
+		OutputDir:    genDir,
+		InstanceName: instanceName,
+		CacheDir:     cacheDir,
+		NoCache:      noCache,
+	}); err != nil {
+		return err
+	}
+
+	if err := gen.AppendInstanceInit(genDir, instanceName); err != nil {
+		return err
+	}
+
+	return gen.Archive(genDir, format, output)
+}
+
+// warnIfNoAnnotations scans searchDir for swag annotations, using the parse
+// cache to skip files that haven't changed since the last run, and prints a
+// warning if none are found. A missing docs comment is almost always a
+// mistake, but not fatal: gen.New().Build still runs and reports its own
+// errors.
+func warnIfNoAnnotations(cacheDir, searchDir string) error {
+	cache, err := parsercache.New(cacheDir, 0)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range strings.Split(searchDir, ",") {
+		annotated, err := cache.AnnotatedFiles(dir)
+		if err != nil {
+			return err
+		}
+		if len(annotated) > 0 {
+			return nil
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "swag init: no swag annotations found under %s\n", searchDir)
+	return nil
+}
@@ -0,0 +1,23 @@
+// Command swag is the CLI entry point for this module: it generates OpenAPI
+// specs from annotated Go source and hosts or manages them.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+)
+
+func main() {
+	app := &cli.App{
+		Name:     "swag",
+		Usage:    "Automatically generate RESTful API documentation with Swagger annotations",
+		Commands: []*cli.Command{initCommand, fmtCommand, serveCommand, cacheCommand},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/txix-open/swag/gen"
+	"github.com/txix-open/swag/internal/swaggerui"
+	"github.com/urfave/cli/v2"
+)
+
+const swaggerUIMountPath = "/swagger/"
+
+var serveFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "host",
+		Value: "localhost",
+		Usage: "host the server listens on",
+	},
+	&cli.IntFlag{
+		Name:    "port",
+		Aliases: []string{"p"},
+		Value:   8080,
+		Usage:   "port the server listens on",
+	},
+	&cli.StringFlag{
+		Name:  "docName",
+		Value: "swagger",
+		Usage: "name of the swag instance to generate and serve",
+	},
+	&cli.StringFlag{
+		Name:    "dir",
+		Aliases: []string{"d"},
+		Value:   "./",
+		Usage:   "directories you want to parse,comma separated and general-info file must be in the first one",
+	},
+	&cli.StringFlag{
+		Name:  "docPath",
+		Value: "/swagger/doc.json",
+		Usage: "path the generated OpenAPI document is served at",
+	},
+	&cli.BoolFlag{
+		Name:  "watch",
+		Usage: "watch dir for *.go changes and regenerate the spec automatically",
+	},
+	cacheDirFlag,
+	noCacheFlag,
+}
+
+var serveCommand = &cli.Command{
+	Name:   "serve",
+	Usage:  "host the generated Swagger UI and OpenAPI document over HTTP",
+	Action: runServe,
+	Flags:  serveFlags,
+}
+
+func runServe(ctx *cli.Context) error {
+	cacheDir, err := cacheDirFor(ctx)
+	if err != nil {
+		return err
+	}
+	config := &Serve{
+		Host:      ctx.String("host"),
+		Port:      ctx.Int("port"),
+		DocName:   ctx.String("docName"),
+		SearchDir: ctx.String("dir"),
+		DocPath:   ctx.String("docPath"),
+		Watch:     ctx.Bool("watch"),
+		CacheDir:  cacheDir,
+		NoCache:   ctx.Bool("noCache"),
+	}
+	return config.Run(ctx.Context)
+}
+
+// Serve holds the configuration for the `swag serve` command.
+type Serve struct {
+	Host      string
+	Port      int
+	DocName   string
+	SearchDir string
+	DocPath   string
+	Watch     bool
+	CacheDir  string
+	NoCache   bool
+
+	// outputDir is where each regenerate() writes docs.go/swagger.json/
+	// swagger.yaml. serveDoc reads the spec straight out of this directory,
+	// so the server never depends on a generated docs package having been
+	// imported into this binary.
+	outputDir string
+}
+
+// Run starts the HTTP server and blocks until ctx is cancelled or the server
+// fails.
+func (s *Serve) Run(ctx context.Context) error {
+	outputDir, err := os.MkdirTemp("", "swag-serve-*")
+	if err != nil {
+		return fmt.Errorf("serve: %w", err)
+	}
+	s.outputDir = outputDir
+	defer os.RemoveAll(outputDir)
+
+	if err := s.regenerate(); err != nil {
+		return fmt.Errorf("serve: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(s.DocPath, s.serveDoc)
+	mux.Handle(swaggerUIMountPath, http.StripPrefix(strings.TrimSuffix(swaggerUIMountPath, "/"), swaggerui.Handler(s.DocPath)))
+
+	addr := fmt.Sprintf("%s:%d", s.Host, s.Port)
+	srv := &http.Server{Addr: addr, Handler: mux}
+
+	if s.Watch {
+		stop, err := s.watch()
+		if err != nil {
+			return fmt.Errorf("serve: %w", err)
+		}
+		defer stop()
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("swag serve: listening on http://%s%s, UI at http://%s%s\n", addr, s.DocPath, addr, swaggerUIMountPath)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve: %w", err)
+	}
+	return nil
+}
+
+func (s *Serve) serveDoc(w http.ResponseWriter, r *http.Request) {
+	specFile := "swagger.json"
+	contentType := "application/json"
+	if strings.HasSuffix(s.DocPath, ".yaml") || strings.HasSuffix(s.DocPath, ".yml") {
+		specFile = "swagger.yaml"
+		contentType = "application/yaml"
+	}
+
+	contents, err := os.ReadFile(filepath.Join(s.outputDir, specFile))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(contents)
+}
+
+func (s *Serve) regenerate() error {
+	return gen.New().Build(&gen.Config{
+		SearchDir:    s.SearchDir,
+		OutputDir:    s.outputDir,
+		InstanceName: s.DocName,
+		CacheDir:     s.CacheDir,
+		NoCache:      s.NoCache,
+	})
+}
+
+// watch starts an fsnotify watcher over every comma-separated entry in
+// SearchDir (and all of their subdirectories, since fsnotify watches are not
+// recursive) and regenerates the spec on every *.go write, logging (but not
+// failing the server on) generation errors.
+func (s *Serve) watch() (func(), error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, searchDir := range strings.Split(s.SearchDir, ",") {
+		if err := addWatchDirs(watcher, searchDir); err != nil {
+			_ = watcher.Close()
+			return nil, err
+		}
+	}
+
+	go func() {
+		for event := range watcher.Events {
+			if !strings.HasSuffix(event.Name, ".go") {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if err := s.regenerate(); err != nil {
+				log.Printf("swag serve: regeneration failed: %v\n", err)
+			}
+		}
+	}()
+
+	return func() { _ = watcher.Close() }, nil
+}
+
+// addWatchDirs adds root and every directory beneath it to watcher, skipping
+// hidden directories the same way format.Format does.
+func addWatchDirs(watcher *fsnotify.Watcher, root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if base := filepath.Base(path); len(base) > 1 && base[0] == '.' {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/txix-open/swag"
 )
 
@@ -18,6 +19,15 @@ type Format struct {
 
 	// exclude exclude dirs and files in SearchDir
 	exclude map[string]bool
+
+	// needsFormat collects paths whose formatted output differs from the
+	// file on disk, populated when Config.CheckOnly or Config.List is set.
+	needsFormat []string
+
+	// checkOnly and list mirror Config.CheckOnly/Config.List for the
+	// duration of a Build call.
+	checkOnly bool
+	list      bool
 }
 
 // New creates a new Format instance
@@ -38,12 +48,37 @@ type Config struct {
 
 	// MainFile (DEPRECATED)
 	MainFile string
+
+	// CheckOnly reports files that need formatting instead of rewriting
+	// them, printing a unified diff for each. Build returns
+	// *ErrNeedsFormatting when any file differs, so CI can fail the build
+	// with `swag fmt --check ./... || exit 1`.
+	CheckOnly bool
+
+	// List is like CheckOnly but prints only the paths that need
+	// formatting, without a diff.
+	List bool
+}
+
+// ErrNeedsFormatting is returned by Format.Build when Config.CheckOnly or
+// Config.List is set and one or more files would be changed by formatting.
+type ErrNeedsFormatting struct {
+	// Paths lists the files whose formatted output differs from disk.
+	Paths []string
+}
+
+func (e *ErrNeedsFormatting) Error() string {
+	return fmt.Sprintf("%d file(s) need formatting", len(e.Paths))
 }
 
 var defaultExcludes = []string{"docs", "vendor"}
 
 // Build runs formatter according to configuration in config
 func (f *Format) Build(config *Config) error {
+	f.checkOnly = config.CheckOnly
+	f.list = config.List
+	f.needsFormat = nil
+
 	searchDirs := strings.Split(config.SearchDir, ",")
 	for _, searchDir := range searchDirs {
 		if _, err := os.Stat(searchDir); os.IsNotExist(err) {
@@ -64,6 +99,9 @@ func (f *Format) Build(config *Config) error {
 			return err
 		}
 	}
+	if len(f.needsFormat) > 0 {
+		return &ErrNeedsFormatting{Paths: f.needsFormat}
+	}
 	return nil
 }
 
@@ -107,9 +145,34 @@ func (f *Format) format(path string) error {
 		// Skip write if no change
 		return nil
 	}
+	if f.checkOnly || f.list {
+		f.needsFormat = append(f.needsFormat, path)
+		if f.list {
+			_, err := fmt.Fprintln(os.Stdout, path)
+			return err
+		}
+		return printDiff(os.Stdout, path, original, formatted)
+	}
 	return write(path, formatted)
 }
 
+// printDiff writes a unified diff between original and formatted to w, in
+// the style of `gofmt -d`.
+func printDiff(w io.Writer, path string, original, formatted []byte) error {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(original)),
+		B:        difflib.SplitLines(string(formatted)),
+		FromFile: path + ".orig",
+		ToFile:   path,
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(w, diff)
+	return err
+}
+
 func write(path string, contents []byte) error {
 	originalFileInfo, err := os.Stat(path)
 	if err != nil {
@@ -148,3 +211,20 @@ func (f *Format) Run(src io.Reader, dst io.Writer) error {
 	}
 	return nil
 }
+
+// Check reports whether the contents read from src are already formatted,
+// without writing anything. It is the programmatic sibling of Run, for
+// callers that want to check formatting without a destination to write to.
+func (f *Format) Check(src io.Reader) (bool, error) {
+	original, err := io.ReadAll(src)
+	if err != nil {
+		return false, err
+	}
+	contents := make([]byte, len(original))
+	copy(contents, original)
+	formatted, err := f.formatter.Format("", contents)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(original, formatted), nil
+}
@@ -0,0 +1,107 @@
+package format
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/txix-open/swag"
+)
+
+func TestErrNeedsFormattingError(t *testing.T) {
+	err := &ErrNeedsFormatting{Paths: []string{"a.go", "b.go"}}
+	if got, want := err.Error(), "2 file(s) need formatting"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestPrintDiff(t *testing.T) {
+	var buf bytes.Buffer
+	err := printDiff(&buf, "example.go", []byte("package a\n"), []byte("package b\n"))
+	if err != nil {
+		t.Fatalf("printDiff() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"--- example.go.orig", "+++ example.go", "-package a", "+package b"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("printDiff() output missing %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestExcludeDirAndFile(t *testing.T) {
+	f := &Format{exclude: map[string]bool{"docs": true}}
+
+	if !f.excludeDir("docs") {
+		t.Error("excludeDir(\"docs\") = false, want true (configured exclude)")
+	}
+	if !f.excludeDir(".git") {
+		t.Error("excludeDir(\".git\") = false, want true (hidden dir)")
+	}
+	if f.excludeDir("pkg") {
+		t.Error("excludeDir(\"pkg\") = true, want false")
+	}
+
+	if !f.excludeFile("format_test.go") {
+		t.Error("excludeFile(\"format_test.go\") = false, want true (_test.go)")
+	}
+	if !f.excludeFile("README.md") {
+		t.Error("excludeFile(\"README.md\") = false, want true (non-.go)")
+	}
+	if f.excludeFile("format.go") {
+		t.Error("excludeFile(\"format.go\") = true, want false")
+	}
+}
+
+func TestBuildListPrintsPaths(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	src := "package main\n\n// Ping godoc\n//@Summary ping\n//@Description ping the server\nfunc Ping() {}\n"
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+
+	f := &Format{
+		exclude:   map[string]bool{},
+		formatter: swag.NewFormatter(),
+	}
+
+	stdout := captureStdout(t, func() {
+		if err := f.Build(&Config{SearchDir: dir, List: true}); err == nil {
+			t.Fatal("Build() with a misformatted file = nil error, want *ErrNeedsFormatting")
+		}
+	})
+
+	if !strings.Contains(stdout, path) {
+		t.Errorf("Build() with List=true did not print %q, got:\n%s", path, stdout)
+	}
+	if strings.Contains(stdout, "---") {
+		t.Errorf("Build() with List=true printed a diff, want paths only:\n%s", stdout)
+	}
+}
+
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	fn()
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("close pipe writer: %v", err)
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read pipe: %v", err)
+	}
+	return string(out)
+}
@@ -0,0 +1,153 @@
+package gen
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// OutputFormat selects how `swag init` delivers generated docs: as the files
+// Gen.Build writes, or packaged by Archive.
+type OutputFormat string
+
+const (
+	// OutputFormatDir writes docs.go, swagger.json and swagger.yaml into
+	// OutputDir as plain files. This is the default.
+	OutputFormatDir OutputFormat = "dir"
+	// OutputFormatTar streams the same files as an uncompressed tarball.
+	OutputFormatTar OutputFormat = "tar"
+	// OutputFormatTgz streams the same files as a gzip-compressed tarball.
+	OutputFormatTgz OutputFormat = "tgz"
+	// OutputFormatZip streams the same files as a zip archive.
+	OutputFormatZip OutputFormat = "zip"
+)
+
+// outputToStdout is the --output value that requests the archive be streamed
+// to stdout rather than written to a file.
+const outputToStdout = "-"
+
+// archiveFile is a single generated document to include in an archive.
+type archiveFile struct {
+	name     string
+	contents []byte
+}
+
+// writeArchive streams files through the archive format named by format into
+// w. OutputFormatDir is not an archive format and is rejected like any
+// unknown value.
+func writeArchive(w io.Writer, format OutputFormat, files []archiveFile) error {
+	switch format {
+	case OutputFormatTar:
+		return writeTar(w, files)
+	case OutputFormatTgz:
+		gzw := gzip.NewWriter(w)
+		if err := writeTar(gzw, files); err != nil {
+			return err
+		}
+		return gzw.Close()
+	case OutputFormatZip:
+		return writeZip(w, files)
+	default:
+		return fmt.Errorf("gen: unknown output format %q", format)
+	}
+}
+
+func writeTar(w io.Writer, files []archiveFile) error {
+	tw := tar.NewWriter(w)
+	modTime := time.Now()
+	for _, f := range files {
+		hdr := &tar.Header{
+			Name:    f.name,
+			Mode:    0o644,
+			Size:    int64(len(f.contents)),
+			ModTime: modTime,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("gen: tar header for %s: %w", f.name, err)
+		}
+		if _, err := tw.Write(f.contents); err != nil {
+			return fmt.Errorf("gen: tar write %s: %w", f.name, err)
+		}
+	}
+	return tw.Close()
+}
+
+func writeZip(w io.Writer, files []archiveFile) error {
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return fmt.Errorf("gen: zip entry for %s: %w", f.name, err)
+		}
+		if _, err := fw.Write(f.contents); err != nil {
+			return fmt.Errorf("gen: zip write %s: %w", f.name, err)
+		}
+	}
+	return zw.Close()
+}
+
+// generatedFiles are the files a plain Gen.Build(OutputDir: dir) run
+// produces; Archive requires all of these and packages them along with the
+// aliases below.
+var generatedFiles = []string{"docs.go", "swagger.json", "swagger.yaml"}
+
+// optionalAliasFiles are included under their own name when present in
+// genDir, but aren't produced by every Gen.Build run, so their absence isn't
+// an error.
+var optionalAliasFiles = []string{"README", "README.md"}
+
+// Archive reads the docs.go, swagger.json and swagger.yaml that a prior
+// Gen.Build(&Config{OutputDir: genDir, ...}) wrote to genDir, plus an
+// openapi.yaml alias and any optionalAliasFiles present, and streams them
+// through format into dest ("-" for stdout).
+func Archive(genDir string, format OutputFormat, dest string) error {
+	files := make([]archiveFile, 0, len(generatedFiles)+2)
+	for _, name := range generatedFiles {
+		contents, err := os.ReadFile(filepath.Join(genDir, name))
+		if err != nil {
+			return fmt.Errorf("gen: read %s: %w", name, err)
+		}
+		files = append(files, archiveFile{name: name, contents: contents})
+		if name == "swagger.yaml" {
+			files = append(files, archiveFile{name: "openapi.yaml", contents: contents})
+		}
+	}
+
+	for _, name := range optionalAliasFiles {
+		contents, err := os.ReadFile(filepath.Join(genDir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("gen: read %s: %w", name, err)
+		}
+		files = append(files, archiveFile{name: name, contents: contents})
+	}
+
+	w, closeOutput, err := openOutput(dest)
+	if err != nil {
+		return err
+	}
+	defer closeOutput()
+
+	return writeArchive(w, format, files)
+}
+
+// openOutput returns the writer generated archives should be streamed to, and
+// a close function that must always be called. dest of "-" streams to
+// os.Stdout; anything else is created as a regular file.
+func openOutput(dest string) (io.Writer, func() error, error) {
+	if dest == outputToStdout {
+		return os.Stdout, func() error { return nil }, nil
+	}
+	f, err := os.Create(dest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("gen: create output %s: %w", dest, err)
+	}
+	return f, f.Close, nil
+}
@@ -0,0 +1,178 @@
+package gen
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeGenDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"docs.go":      "package docs\n",
+		"swagger.json": `{"info":{"title":"t"}}`,
+		"swagger.yaml": "info:\n  title: t\n",
+	}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestWriteTar(t *testing.T) {
+	var buf bytes.Buffer
+	files := []archiveFile{{name: "a.txt", contents: []byte("hello")}}
+	if err := writeTar(&buf, files); err != nil {
+		t.Fatalf("writeTar() error = %v", err)
+	}
+
+	tr := tar.NewReader(&buf)
+	hdr, err := tr.Next()
+	if err != nil {
+		t.Fatalf("tar.Next() error = %v", err)
+	}
+	if hdr.Name != "a.txt" {
+		t.Errorf("tar entry name = %q, want %q", hdr.Name, "a.txt")
+	}
+	got, err := io.ReadAll(tr)
+	if err != nil {
+		t.Fatalf("read tar entry: %v", err)
+	}
+	if string(got) != "hello" {
+		t.Errorf("tar entry contents = %q, want %q", got, "hello")
+	}
+}
+
+func TestWriteZip(t *testing.T) {
+	var buf bytes.Buffer
+	files := []archiveFile{{name: "a.txt", contents: []byte("hello")}}
+	if err := writeZip(&buf, files); err != nil {
+		t.Fatalf("writeZip() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	if len(zr.File) != 1 || zr.File[0].Name != "a.txt" {
+		t.Fatalf("zip entries = %+v, want single entry named a.txt", zr.File)
+	}
+	rc, err := zr.File[0].Open()
+	if err != nil {
+		t.Fatalf("open zip entry: %v", err)
+	}
+	defer rc.Close()
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read zip entry: %v", err)
+	}
+	if string(got) != "hello" {
+		t.Errorf("zip entry contents = %q, want %q", got, "hello")
+	}
+}
+
+func TestWriteArchiveTgz(t *testing.T) {
+	var buf bytes.Buffer
+	files := []archiveFile{{name: "a.txt", contents: []byte("hello")}}
+	if err := writeArchive(&buf, OutputFormatTgz, files); err != nil {
+		t.Fatalf("writeArchive() error = %v", err)
+	}
+
+	gzr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	tr := tar.NewReader(gzr)
+	hdr, err := tr.Next()
+	if err != nil {
+		t.Fatalf("tar.Next() error = %v", err)
+	}
+	if hdr.Name != "a.txt" {
+		t.Errorf("tar entry name = %q, want %q", hdr.Name, "a.txt")
+	}
+}
+
+func TestWriteArchiveUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeArchive(&buf, OutputFormat("bogus"), nil); err == nil {
+		t.Fatal("writeArchive() with unknown format = nil error, want error")
+	}
+}
+
+func TestOpenOutputStdout(t *testing.T) {
+	w, closeOutput, err := openOutput(outputToStdout)
+	if err != nil {
+		t.Fatalf("openOutput() error = %v", err)
+	}
+	if w != os.Stdout {
+		t.Error("openOutput(\"-\") did not return os.Stdout")
+	}
+	if err := closeOutput(); err != nil {
+		t.Errorf("closeOutput() error = %v", err)
+	}
+}
+
+func TestOpenOutputFile(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "out.zip")
+	w, closeOutput, err := openOutput(dest)
+	if err != nil {
+		t.Fatalf("openOutput() error = %v", err)
+	}
+	if _, err := w.Write([]byte("data")); err != nil {
+		t.Fatalf("write to output: %v", err)
+	}
+	if err := closeOutput(); err != nil {
+		t.Fatalf("closeOutput() error = %v", err)
+	}
+
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("read output file: %v", err)
+	}
+	if string(got) != "data" {
+		t.Errorf("output file contents = %q, want %q", got, "data")
+	}
+}
+
+func TestArchiveWritesZipWithAliasesAndOptionalReadme(t *testing.T) {
+	genDir := writeGenDir(t)
+	if err := os.WriteFile(filepath.Join(genDir, "README.md"), []byte("# docs"), 0o644); err != nil {
+		t.Fatalf("write README.md: %v", err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "out.zip")
+	if err := Archive(genDir, OutputFormatZip, dest); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+
+	zr, err := zip.OpenReader(dest)
+	if err != nil {
+		t.Fatalf("zip.OpenReader() error = %v", err)
+	}
+	defer zr.Close()
+
+	names := make(map[string]bool)
+	for _, f := range zr.File {
+		names[f.Name] = true
+	}
+	for _, want := range []string{"docs.go", "swagger.json", "swagger.yaml", "openapi.yaml", "README.md"} {
+		if !names[want] {
+			t.Errorf("archive missing %q, got %v", want, names)
+		}
+	}
+}
+
+func TestArchiveMissingGeneratedFile(t *testing.T) {
+	genDir := t.TempDir()
+	if err := Archive(genDir, OutputFormatTar, "-"); err == nil {
+		t.Fatal("Archive() on empty genDir = nil error, want error")
+	}
+}
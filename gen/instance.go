@@ -0,0 +1,91 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+// instanceInitTemplate renders the per-instance init() block appended to
+// docs.go, registering the spec's metadata via swag.RegisterMeta alongside
+// the existing swag.Register call.
+var instanceInitTemplate = template.Must(template.New("instanceInit").Parse(`
+func init() {
+	swag.RegisterMeta(SwaggerInfo{{.InstanceName}}.InstanceName(), swag.InstanceMeta{
+		Title:    {{printf "%q" .Title}},
+		Version:  {{printf "%q" .Version}},
+		BasePath: {{printf "%q" .BasePath}},
+	})
+	swag.Register(SwaggerInfo{{.InstanceName}}.InstanceName(), SwaggerInfo{{.InstanceName}})
+}
+`))
+
+// InstanceMeta describes the metadata gen emits alongside a generated spec
+// so it can be registered under its instance name.
+type InstanceMeta struct {
+	InstanceName string
+	Title        string
+	Version      string
+	BasePath     string
+}
+
+// renderInstanceInit renders the init() block for a single generated
+// instance, to be appended to docs.go after the existing SwaggerInfo
+// variable declaration.
+func renderInstanceInit(meta InstanceMeta) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := instanceInitTemplate.Execute(&buf, meta); err != nil {
+		return nil, fmt.Errorf("gen: render instance init for %s: %w", meta.InstanceName, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// specInfo is the subset of swagger.json this package reads back out to
+// build an InstanceMeta.
+type specInfo struct {
+	BasePath string `json:"basePath"`
+	Info     struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+}
+
+// AppendInstanceInit reads the swagger.json that Gen.Build wrote to genDir
+// and appends the instance's init() block (see renderInstanceInit) to
+// docs.go in the same directory, so the generated package registers its
+// metadata via swag.RegisterMeta when imported.
+func AppendInstanceInit(genDir, instanceName string) error {
+	rawSpec, err := os.ReadFile(filepath.Join(genDir, "swagger.json"))
+	if err != nil {
+		return fmt.Errorf("gen: read swagger.json: %w", err)
+	}
+
+	var spec specInfo
+	if err := json.Unmarshal(rawSpec, &spec); err != nil {
+		return fmt.Errorf("gen: parse swagger.json: %w", err)
+	}
+
+	init, err := renderInstanceInit(InstanceMeta{
+		InstanceName: instanceName,
+		Title:        spec.Info.Title,
+		Version:      spec.Info.Version,
+		BasePath:     spec.BasePath,
+	})
+	if err != nil {
+		return err
+	}
+
+	docsGo, err := os.OpenFile(filepath.Join(genDir, "docs.go"), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("gen: open docs.go: %w", err)
+	}
+	defer docsGo.Close()
+
+	if _, err := docsGo.Write(init); err != nil {
+		return fmt.Errorf("gen: append instance init to docs.go: %w", err)
+	}
+	return docsGo.Close()
+}
@@ -0,0 +1,72 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderInstanceInit(t *testing.T) {
+	out, err := renderInstanceInit(InstanceMeta{
+		InstanceName: "API",
+		Title:        "My API",
+		Version:      "1.0",
+		BasePath:     "/api",
+	})
+	if err != nil {
+		t.Fatalf("renderInstanceInit() error = %v", err)
+	}
+
+	got := string(out)
+	for _, want := range []string{
+		"SwaggerInfoAPI.InstanceName()",
+		`Title:    "My API"`,
+		`Version:  "1.0"`,
+		`BasePath: "/api"`,
+		"swag.RegisterMeta(",
+		"swag.Register(",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderInstanceInit() output missing %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestAppendInstanceInit(t *testing.T) {
+	dir := t.TempDir()
+
+	const swaggerJSON = `{"basePath":"/api","info":{"title":"My API","version":"1.0"}}`
+	if err := os.WriteFile(filepath.Join(dir, "swagger.json"), []byte(swaggerJSON), 0o644); err != nil {
+		t.Fatalf("write swagger.json: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "docs.go"), []byte("package docs\n"), 0o644); err != nil {
+		t.Fatalf("write docs.go: %v", err)
+	}
+
+	if err := AppendInstanceInit(dir, "API"); err != nil {
+		t.Fatalf("AppendInstanceInit() error = %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "docs.go"))
+	if err != nil {
+		t.Fatalf("read docs.go: %v", err)
+	}
+
+	for _, want := range []string{
+		"package docs",
+		"SwaggerInfoAPI.InstanceName()",
+		`Title:    "My API"`,
+		`BasePath: "/api"`,
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("docs.go missing %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestAppendInstanceInitMissingSpec(t *testing.T) {
+	if err := AppendInstanceInit(t.TempDir(), "API"); err == nil {
+		t.Fatal("AppendInstanceInit() with no swagger.json = nil error, want error")
+	}
+}
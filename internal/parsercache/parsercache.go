@@ -0,0 +1,265 @@
+// Package parsercache provides a persistent, on-disk cache of per-file parse
+// results keyed by file content, so repeated `swag init` runs skip rescanning
+// files that haven't changed. Its only user in this module is the annotation
+// pre-scan of `swag init` (Cache.AnnotatedFiles); the generator's own parsing
+// does not go through this cache.
+package parsercache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is how long a cache entry is considered valid before it is
+// evicted on next lookup, absent an explicit TTL on the Cache.
+const DefaultTTL = 7 * 24 * time.Hour
+
+// Cache is a namespaced, file-backed cache of parse results. A Cache is safe
+// for concurrent use: lookups and stores for distinct keys never block each
+// other, but concurrent callers for the *same* key are serialized so a miss
+// only triggers one parse.
+type Cache struct {
+	dir string
+	ttl time.Duration
+
+	mu      sync.Mutex
+	lockers map[string]*sync.Mutex
+}
+
+// New returns a Cache rooted at dir, creating it if necessary. dir is
+// typically $XDG_CACHE_HOME/swag or the --cacheDir flag value.
+func New(dir string, ttl time.Duration) (*Cache, error) {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("parsercache: create cache dir: %w", err)
+	}
+	return &Cache{
+		dir:     dir,
+		ttl:     ttl,
+		lockers: map[string]*sync.Mutex{},
+	}, nil
+}
+
+// Dir returns the default cache directory: $XDG_CACHE_HOME/swag, falling
+// back to $HOME/.cache/swag when XDG_CACHE_HOME is unset.
+func Dir() (string, error) {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "swag"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("parsercache: resolve home dir: %w", err)
+	}
+	return filepath.Join(home, ".cache", "swag"), nil
+}
+
+// entry is the on-disk envelope wrapping a cached parse result.
+type entry struct {
+	StoredAt time.Time       `json:"storedAt"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// ErrMiss is returned by Get when key is not present or has expired.
+var ErrMiss = errors.New("parsercache: cache miss")
+
+// Key derives a cache key from the contents of a parsed file plus any
+// parser options that affect its result (swag version, parseInternal,
+// parseDependency, and so on). Callers should include every option that
+// changes parse output so stale entries are never reused across runs with
+// different flags.
+func Key(contents []byte, opts ...string) string {
+	h := sha256.New()
+	h.Write(contents)
+	for _, opt := range opts {
+		h.Write([]byte{0})
+		h.Write([]byte(opt))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get looks up key and decodes it into v. It returns ErrMiss if the key is
+// absent or its entry is older than the cache's TTL.
+func (c *Cache) Get(key string, v any) error {
+	raw, err := os.ReadFile(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrMiss
+	}
+	if err != nil {
+		return fmt.Errorf("parsercache: read %s: %w", key, err)
+	}
+
+	var e entry
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return fmt.Errorf("parsercache: decode %s: %w", key, err)
+	}
+	if time.Since(e.StoredAt) > c.ttl {
+		_ = os.Remove(c.path(key))
+		return ErrMiss
+	}
+	if err := json.Unmarshal(e.Value, v); err != nil {
+		return fmt.Errorf("parsercache: decode value for %s: %w", key, err)
+	}
+	return nil
+}
+
+// Set stores v under key, replacing any existing entry.
+func (c *Cache) Set(key string, v any) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("parsercache: encode value for %s: %w", key, err)
+	}
+	raw, err := json.Marshal(entry{StoredAt: time.Now(), Value: value})
+	if err != nil {
+		return fmt.Errorf("parsercache: encode %s: %w", key, err)
+	}
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return fmt.Errorf("parsercache: create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// Locker returns the mutex guarding key, creating it on first use. Callers
+// parsing the same file concurrently (e.g. under -parseInternal) should hold
+// this lock across their Get-miss-parse-Set sequence so only one goroutine
+// does the work.
+func (c *Cache) Locker(key string) *sync.Mutex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l, ok := c.lockers[key]
+	if !ok {
+		l = &sync.Mutex{}
+		c.lockers[key] = l
+	}
+	return l
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// ParseFile wraps an expensive per-file parse: it looks up key (typically
+// Key(fileContents, option, ...)) and decodes the cached result into dest on
+// a hit. On a miss it calls parse to produce dest itself, then writes dest
+// back for next time. Concurrent callers for the same key are serialized on
+// Locker so only one of them actually parses. Its only caller in this module
+// is AnnotatedFiles; the generator's file and package loading do not use it.
+func (c *Cache) ParseFile(key string, dest any, parse func() error) error {
+	locker := c.Locker(key)
+	locker.Lock()
+	defer locker.Unlock()
+
+	err := c.Get(key, dest)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrMiss) {
+		return err
+	}
+
+	if err := parse(); err != nil {
+		return err
+	}
+	return c.Set(key, dest)
+}
+
+// annotationMarkers are the swag comment tags whose presence marks a Go file
+// as containing API documentation worth generating from.
+var annotationMarkers = []string{"@title", "@Summary", "@Router", "@Description", "@Param"}
+
+// annotationScan is the cached result of scanning a single file for swag
+// annotations.
+type annotationScan struct {
+	Annotated bool `json:"annotated"`
+}
+
+// AnnotatedFiles walks searchDir and returns the paths of .go files
+// containing at least one swag annotation comment. Each file's scan result
+// is cached via ParseFile, keyed on its contents, so repeated calls across
+// `swag init` runs only rescan files that changed.
+func (c *Cache) AnnotatedFiles(searchDir string) ([]string, error) {
+	var annotated []string
+	err := filepath.Walk(searchDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".go" {
+			return nil
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var scan annotationScan
+		key := Key(contents, "annotated")
+		if err := c.ParseFile(key, &scan, func() error {
+			scan.Annotated = containsAnnotation(contents)
+			return nil
+		}); err != nil {
+			return err
+		}
+		if scan.Annotated {
+			annotated = append(annotated, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parsercache: scan %s: %w", searchDir, err)
+	}
+	return annotated, nil
+}
+
+func containsAnnotation(src []byte) bool {
+	for _, marker := range annotationMarkers {
+		if bytes.Contains(src, []byte(marker)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Clean removes every entry older than the cache's TTL and returns how many
+// were removed. It backs the `swag cache clean` subcommand.
+func (c *Cache) Clean() (int, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return 0, fmt.Errorf("parsercache: read cache dir: %w", err)
+	}
+
+	removed := 0
+	for _, de := range entries {
+		if de.IsDir() {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+		if time.Since(info.ModTime()) > c.ttl {
+			if err := os.Remove(filepath.Join(c.dir, de.Name())); err != nil {
+				return removed, fmt.Errorf("parsercache: remove %s: %w", de.Name(), err)
+			}
+			removed++
+		}
+	}
+	return removed, nil
+}
@@ -0,0 +1,139 @@
+package parsercache
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type parsedFile struct {
+	Ops int `json:"ops"`
+}
+
+func TestCacheGetSetRoundTrip(t *testing.T) {
+	cache, err := New(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	key := Key([]byte("package main"), "v1", "parseInternal=false")
+
+	var got parsedFile
+	if err := cache.Get(key, &got); !errors.Is(err, ErrMiss) {
+		t.Fatalf("Get() before Set() = %v, want ErrMiss", err)
+	}
+
+	want := parsedFile{Ops: 3}
+	if err := cache.Set(key, want); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	if err := cache.Get(key, &got); err != nil {
+		t.Fatalf("Get() after Set() error = %v", err)
+	}
+	if got != want {
+		t.Fatalf("Get() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCacheParseFileOnlyParsesOnMiss(t *testing.T) {
+	cache, err := New(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	key := Key([]byte("package main"))
+	calls := 0
+	parse := func(dest *parsedFile) func() error {
+		return func() error {
+			calls++
+			*dest = parsedFile{Ops: 1}
+			return nil
+		}
+	}
+
+	var first parsedFile
+	if err := cache.ParseFile(key, &first, parse(&first)); err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	var second parsedFile
+	if err := cache.ParseFile(key, &second, parse(&second)); err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("parse called %d times, want 1 (second call should hit the cache)", calls)
+	}
+	if second != first {
+		t.Fatalf("ParseFile() second = %+v, want %+v", second, first)
+	}
+}
+
+func TestCacheCleanRemovesExpiredEntries(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := New(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := cache.Set("fresh", parsedFile{Ops: 1}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := cache.Set("stale", parsedFile{Ops: 2}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "stale.json"), old, old); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	removed, err := cache.Clean()
+	if err != nil {
+		t.Fatalf("Clean() error = %v", err)
+	}
+	if removed != 1 {
+		t.Fatalf("Clean() removed = %d, want 1", removed)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "fresh.json")); err != nil {
+		t.Fatalf("fresh entry should survive Clean(): %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "stale.json")); !os.IsNotExist(err) {
+		t.Fatalf("stale entry should be removed by Clean(), stat err = %v", err)
+	}
+}
+
+func TestCacheAnnotatedFiles(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := New(filepath.Join(dir, "cache"), time.Hour)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	annotatedPath := filepath.Join(dir, "handler.go")
+	plainPath := filepath.Join(dir, "util.go")
+	if err := os.WriteFile(annotatedPath, []byte("package main\n\n// @Summary ping\nfunc Ping() {}\n"), 0o644); err != nil {
+		t.Fatalf("write annotated file: %v", err)
+	}
+	if err := os.WriteFile(plainPath, []byte("package main\n\nfunc Util() {}\n"), 0o644); err != nil {
+		t.Fatalf("write plain file: %v", err)
+	}
+
+	got, err := cache.AnnotatedFiles(dir)
+	if err != nil {
+		t.Fatalf("AnnotatedFiles() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != annotatedPath {
+		t.Fatalf("AnnotatedFiles() = %v, want [%s]", got, annotatedPath)
+	}
+
+	// AnnotatedFiles caches its scan result via ParseFile, keyed on contents.
+	key := Key([]byte("package main\n\n// @Summary ping\nfunc Ping() {}\n"), "annotated")
+	if _, err := os.Stat(filepath.Join(filepath.Join(dir, "cache"), key+".json")); err != nil {
+		t.Fatalf("expected cache entry for annotated file: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+// Package swaggerui embeds a small, dependency-free API explorer so that the
+// `swag serve` command can host an interactive UI without depending on any
+// external assets or network access at runtime.
+package swaggerui
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed all:dist
+var distFS embed.FS
+
+// FS returns the embedded UI assets, rooted at their static asset
+// directory.
+func FS() fs.FS {
+	sub, err := fs.Sub(distFS, "dist")
+	if err != nil {
+		// The dist directory is embedded at build time, so this can only
+		// happen if the package itself is broken.
+		panic(fmt.Sprintf("swaggerui: invalid embedded assets: %v", err))
+	}
+	return sub
+}
+
+// Handler returns an http.Handler that serves the embedded UI. docURL is the
+// absolute or relative URL the UI should fetch the OpenAPI document from,
+// e.g. "/swagger/doc.json".
+func Handler(docURL string) http.Handler {
+	index, err := renderIndex(docURL)
+	if err != nil {
+		panic(fmt.Sprintf("swaggerui: %v", err))
+	}
+	return &handler{
+		assets: http.FileServer(http.FS(FS())),
+		index:  index,
+	}
+}
+
+type handler struct {
+	assets http.Handler
+	index  []byte
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/" || r.URL.Path == "/index.html" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(h.index)
+		return
+	}
+	h.assets.ServeHTTP(w, r)
+}
+
+// renderIndex patches the bundled index.html template so the UI fetches
+// docURL instead of a hardcoded spec location.
+func renderIndex(docURL string) ([]byte, error) {
+	raw, err := distFS.ReadFile("dist/index.html")
+	if err != nil {
+		return nil, fmt.Errorf("missing index.html in embedded assets: %w", err)
+	}
+	quoted, err := json.Marshal(docURL)
+	if err != nil {
+		return nil, fmt.Errorf("encode doc URL: %w", err)
+	}
+	return bytes.ReplaceAll(raw, []byte("{{.DocURL}}"), quoted), nil
+}
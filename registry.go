@@ -0,0 +1,127 @@
+package swag
+
+import (
+	"crypto/sha1" //nolint:gosec // used only to derive an ETag, not for security
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// InstanceMeta describes the title, version and base path a generated
+// instance was built with.
+type InstanceMeta struct {
+	Title    string
+	Version  string
+	BasePath string
+}
+
+var (
+	instanceMetaMu sync.RWMutex
+	instanceMeta   = map[string]InstanceMeta{}
+)
+
+// RegisterMeta records meta for the named instance. It is called from
+// generated init() functions alongside Register.
+func RegisterMeta(name string, meta InstanceMeta) {
+	instanceMetaMu.Lock()
+	defer instanceMetaMu.Unlock()
+	instanceMeta[name] = meta
+}
+
+// Metadata returns the InstanceMeta recorded for name via RegisterMeta, and
+// whether one was found.
+func Metadata(name string) (InstanceMeta, bool) {
+	instanceMetaMu.RLock()
+	defer instanceMetaMu.RUnlock()
+	meta, ok := instanceMeta[name]
+	return meta, ok
+}
+
+// RegisteredDocs returns the instance names of every spec registered via
+// Register, sorted for a stable order.
+func RegisteredDocs() []string {
+	swaggerMu.RLock()
+	defer swaggerMu.RUnlock()
+
+	names := make([]string, 0, len(swags))
+	for name := range swags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MustReadDoc is like ReadDoc but panics if name is not registered.
+func MustReadDoc(name string) string {
+	doc, err := ReadDoc(name)
+	if err != nil {
+		panic(fmt.Sprintf("swag: %v", err))
+	}
+	return doc
+}
+
+// Handler returns a ready-to-mount http.Handler serving the named instance's
+// spec as JSON or YAML, negotiated from the request, with ETag support.
+func Handler(name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		doc, err := ReadDoc(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		etag := `"` + docETag(doc) + `"`
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		if wantsYAML(r) {
+			yamlDoc, err := jsonDocToYAML(doc)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/yaml")
+			_, _ = w.Write(yamlDoc)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(doc))
+	})
+}
+
+func docETag(doc string) string {
+	sum := sha1.Sum([]byte(doc)) //nolint:gosec // content fingerprint, not a security boundary
+	return hex.EncodeToString(sum[:])
+}
+
+// jsonDocToYAML re-encodes a JSON-formatted spec as YAML for clients that
+// asked for it via Handler.
+func jsonDocToYAML(doc string) ([]byte, error) {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(doc), &parsed); err != nil {
+		return nil, fmt.Errorf("swag: decode spec as JSON: %w", err)
+	}
+	yamlDoc, err := yaml.Marshal(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("swag: encode spec as YAML: %w", err)
+	}
+	return yamlDoc, nil
+}
+
+func wantsYAML(r *http.Request) bool {
+	if strings.HasSuffix(r.URL.Path, ".yaml") || strings.HasSuffix(r.URL.Path, ".yml") {
+		return true
+	}
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "yaml") && !strings.Contains(accept, "json")
+}
@@ -0,0 +1,123 @@
+package swag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// testSwagger is a minimal Swagger implementation for exercising Handler and
+// RegisteredDocs without a generated docs package.
+type testSwagger string
+
+func (s testSwagger) ReadDoc() string { return string(s) }
+
+func testSpec(doc string) Swagger { return testSwagger(doc) }
+
+func TestRegisteredDocsIsSortedAndDeduped(t *testing.T) {
+	swaggerMu.Lock()
+	swags = map[string]Swagger{
+		"internal": nil,
+		"public":   nil,
+		"admin":    nil,
+	}
+	swaggerMu.Unlock()
+
+	got := RegisteredDocs()
+	want := []string{"admin", "internal", "public"}
+	if len(got) != len(want) {
+		t.Fatalf("RegisteredDocs() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("RegisteredDocs() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestWantsYAML(t *testing.T) {
+	cases := []struct {
+		name   string
+		path   string
+		accept string
+		want   bool
+	}{
+		{"yaml suffix", "/swagger/doc.yaml", "", true},
+		{"yml suffix", "/swagger/doc.yml", "", true},
+		{"json suffix", "/swagger/doc.json", "", false},
+		{"accept yaml", "/swagger/doc", "application/yaml", true},
+		{"accept json over yaml", "/swagger/doc", "application/yaml, application/json", false},
+		{"no accept", "/swagger/doc", "", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, c.path, nil)
+			if c.accept != "" {
+				r.Header.Set("Accept", c.accept)
+			}
+			if got := wantsYAML(r); got != c.want {
+				t.Errorf("wantsYAML() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestJSONDocToYAML(t *testing.T) {
+	out, err := jsonDocToYAML(`{"info":{"title":"Test API","version":"1.0"}}`)
+	if err != nil {
+		t.Fatalf("jsonDocToYAML() error = %v", err)
+	}
+	if got := string(out); got == "" {
+		t.Fatal("jsonDocToYAML() returned empty output")
+	}
+}
+
+func TestHandlerServesJSONAndETag(t *testing.T) {
+	const instance = "handler-test"
+	const doc = `{"info":{"title":"Test API","version":"1.0"}}`
+
+	Register(instance, testSpec(doc))
+
+	h := Handler(instance)
+
+	r := httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if w.Body.String() != doc {
+		t.Fatalf("body = %q, want %q", w.Body.String(), doc)
+	}
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("ETag header not set")
+	}
+
+	r2 := httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+	r2.Header.Set("If-None-Match", etag)
+	w2 := httptest.NewRecorder()
+	h.ServeHTTP(w2, r2)
+
+	if w2.Code != http.StatusNotModified {
+		t.Fatalf("status with If-None-Match = %d, want 304", w2.Code)
+	}
+}
+
+func TestHandlerServesYAMLOnSuffix(t *testing.T) {
+	const instance = "handler-test-yaml"
+	Register(instance, testSpec(`{"info":{"title":"Test API","version":"1.0"}}`))
+
+	h := Handler(instance)
+	r := httptest.NewRequest(http.MethodGet, "/swagger/doc.yaml", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/yaml" {
+		t.Fatalf("Content-Type = %q, want application/yaml", ct)
+	}
+}